@@ -8,6 +8,7 @@ Package daemon 0.11.0 for use with Go (golang) services.
 Package daemon provides primitives for daemonization of golang services.
 This package is not provide implementation of user daemon,
 accordingly must have root rights to install/remove service.
+The only exception is a per-user agent on darwin (see Launchd.SetUserAgent).
 In the current implementation is only supported Linux and Mac Os X daemon.
 
 Example:
@@ -172,8 +173,14 @@ type Daemon interface {
 	// Stop the service
 	Stop() (string, error)
 
+	// Reload the service (send SIGHUP) without a full restart
+	Reload() (string, error)
+
 	// Status - check the service status
 	Status() (string, error)
+
+	// GetStatus - check the service status as a Status value
+	GetStatus() (Status, error)
   
 	// Installed - check if service is intalled
 	IsInstalled() (bool, error)
@@ -182,6 +189,30 @@ type Daemon interface {
 	Run(e Executable) (string, error)
 }
 
+// Status of the service
+type Status int
+
+// Service statuses which are returned by GetStatus
+const (
+	StatusUnknown Status = iota
+	StatusRunning
+	StatusStopped
+	StatusNotInstalled
+)
+
+// String - readable description of the service status
+func (status Status) String() string {
+	switch status {
+	case StatusRunning:
+		return "Service is running..."
+	case StatusStopped:
+		return "Service is stopped"
+	case StatusNotInstalled:
+		return "Service is not installed"
+	}
+	return "Service status is unknown"
+}
+
 // Executable interface defines controlling methods of executable service
 type Executable interface {
 	// Start - non-blocking start service
@@ -192,6 +223,30 @@ type Executable interface {
 	Run()
 }
 
+// Launchd interface has a set of darwin (launchd) specific settings,
+// the Daemon returned by New implements it on darwin only
+type Launchd interface {
+	// SetKeepAlive - keep the service alive (respawn it) whenever it exits
+	SetKeepAlive(keepAlive bool)
+	// SetRunAtLoad - start the service as soon as it is loaded by launchd
+	SetRunAtLoad(runAtLoad bool)
+	// SetWorkingDirectory - set working directory of the service
+	SetWorkingDirectory(path string)
+	// SetStandardOutPath - set file path where stdout of the service is written
+	SetStandardOutPath(path string)
+	// SetStandardErrorPath - set file path where stderr of the service is written
+	SetStandardErrorPath(path string)
+	// SetUserAgent - install the service as a per-user agent instead of a system daemon
+	SetUserAgent(userAgent bool)
+}
+
+// PidFile interface allows to set a custom pid file of the service,
+// the Daemon returned by New implements it on freebsd and darwin
+type PidFile interface {
+	// SetPidFile - set path of the file which contains pid of the running service
+	SetPidFile(path string)
+}
+
 // New - Create a new daemon
 //
 // name: name of the service
@@ -0,0 +1,28 @@
+// Copyright 2016 The Go Authors. All rights reserved.
+// Use of this source code is governed by
+// license that can be found in the LICENSE file.
+
+//+build darwin freebsd
+//+build go1.8
+
+package daemon
+
+import (
+	"os"
+	"testing"
+)
+
+func TestExecPath(t *testing.T) {
+	expected, err := os.Executable()
+	if err != nil {
+		t.Skip("os.Executable is not available:", err)
+	}
+
+	path, err := execPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != expected {
+		t.Errorf("expected %q, got %q", expected, path)
+	}
+}
@@ -8,8 +8,11 @@ package daemon
 import (
 	"os"
 	"os/exec"
+	"os/user"
 	"path/filepath"
 	"regexp"
+	"strconv"
+	"syscall"
 	"text/template"
 )
 
@@ -19,21 +22,200 @@ type darwinRecord struct {
 	description   string
 	execStartPath string
 	dependencies  []string
+	keepAlive     bool
+	runAtLoad     bool
+	userAgent     bool
+	pidFile       string
+
+	workingDirectory  string
+	standardOutPath   string
+	standardErrorPath string
 }
 
 func newDaemon(name, description, execStartPath string, dependencies []string) (Daemon, error) {
 
-	return &darwinRecord{name, description, execStartPath,dependencies}, nil
+	return &darwinRecord{
+		name:          name,
+		description:   description,
+		execStartPath: execStartPath,
+		dependencies:  dependencies,
+		keepAlive:     true,
+		runAtLoad:     true,
+	}, nil
 }
 
-// Standard service path for system daemons
-func (darwin *darwinRecord) servicePath() string {
-	return "/Library/LaunchDaemons/" + darwin.name + ".plist"
+// SetKeepAlive - set KeepAlive key of the property list (default: true)
+func (darwin *darwinRecord) SetKeepAlive(keepAlive bool) {
+	darwin.keepAlive = keepAlive
+}
+
+// SetRunAtLoad - set RunAtLoad key of the property list (default: true)
+func (darwin *darwinRecord) SetRunAtLoad(runAtLoad bool) {
+	darwin.runAtLoad = runAtLoad
+}
+
+// SetWorkingDirectory - set WorkingDirectory key of the property list
+// (default: /usr/local/var, or home directory for per-user agents),
+// an empty path restores the default
+func (darwin *darwinRecord) SetWorkingDirectory(path string) {
+	darwin.workingDirectory = path
+}
+
+// SetStandardOutPath - set StandardOutPath key of the property list
+// (default: /usr/local/var/log/{name}.log, or ~/Library/Logs/{name}.log
+// for per-user agents), an empty path restores the default
+func (darwin *darwinRecord) SetStandardOutPath(path string) {
+	darwin.standardOutPath = path
+}
+
+// SetStandardErrorPath - set StandardErrorPath key of the property list
+// (default: /usr/local/var/log/{name}.err, or ~/Library/Logs/{name}.err
+// for per-user agents), an empty path restores the default
+func (darwin *darwinRecord) SetStandardErrorPath(path string) {
+	darwin.standardErrorPath = path
+}
+
+// SetUserAgent - install the service into ~/Library/LaunchAgents of the current
+// user instead of /Library/LaunchDaemons (default: false). A user agent does not
+// require root privileges and is loaded into the gui domain of the user, it must
+// be managed by that user and not by root
+func (darwin *darwinRecord) SetUserAgent(userAgent bool) {
+	darwin.userAgent = userAgent
+}
+
+// SetPidFile - set pid file of the service, which is consulted
+// when launchctl does not report pid of the running service
+func (darwin *darwinRecord) SetPidFile(path string) {
+	darwin.pidFile = path
+}
+
+// Get pid of the running service from the pid file if it is set
+func (darwin *darwinRecord) pidFromFile() (int, bool) {
+	if darwin.pidFile == "" {
+		return 0, false
+	}
+	pid, err := readPidFile(darwin.pidFile)
+	if err != nil {
+		return 0, false
+	}
+	// EPERM is not accepted, the pid may be reused by a process of another user
+	if err := syscall.Kill(pid, 0); err != nil {
+		return 0, false
+	}
+	return pid, true
+}
+
+// Get pid of the running service from launchctl,
+// the pid file is consulted as a fallback if it is set
+func (darwin *darwinRecord) getPid() (int, bool) {
+	if output, err := darwin.launchctlStatus(); err == nil {
+		if data := darwin.pidPattern().FindStringSubmatch(output); len(data) > 1 {
+			if pid, err := strconv.Atoi(data[1]); err == nil {
+				return pid, true
+			}
+		}
+	}
+	return darwin.pidFromFile()
+}
+
+// Domain of the current user, where per-user agents are loaded
+func (darwin *darwinRecord) userDomain() string {
+	return "gui/" + strconv.Itoa(os.Getuid())
+}
+
+// Get launchctl description of the loaded service,
+// per-user agents are looked up explicitly in the domain of the user
+func (darwin *darwinRecord) launchctlStatus() (string, error) {
+	if darwin.userAgent {
+		output, err := exec.Command("launchctl", "print", darwin.userDomain()+"/"+darwin.name).Output()
+		return string(output), err
+	}
+	output, err := exec.Command("launchctl", "list", darwin.name).Output()
+	return string(output), err
+}
+
+// Pattern of the pid in launchctl description of the service
+func (darwin *darwinRecord) pidPattern() *regexp.Regexp {
+	if darwin.userAgent {
+		return regexp.MustCompile(`\bpid = ([0-9]+)`)
+	}
+	return regexp.MustCompile("PID\" = ([0-9]+);")
+}
+
+// Standard service path for system daemons or per-user agents
+func (darwin *darwinRecord) servicePath() (string, error) {
+	if darwin.userAgent {
+		home, err := homeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(home, "Library", "LaunchAgents", darwin.name+".plist"), nil
+	}
+	return "/Library/LaunchDaemons/" + darwin.name + ".plist", nil
+}
+
+// Home directory of the current user
+func homeDir() (string, error) {
+	home := os.Getenv("HOME")
+	if home == "" {
+		if current, err := user.Current(); err == nil {
+			home = current.HomeDir
+		}
+	}
+	if !filepath.IsAbs(home) {
+		return "", ErrHomeDirectory
+	}
+	return home, nil
+}
+
+// Get working directory and log paths of the service, paths which are not set
+// by the caller are defaults of system daemons or per-user agents
+func (darwin *darwinRecord) paths() (workingDirectory, standardOutPath, standardErrorPath string, err error) {
+	workingDirectory, logDirectory := "/usr/local/var", "/usr/local/var/log"
+	if darwin.userAgent {
+		home, err := homeDir()
+		if err != nil {
+			return "", "", "", err
+		}
+		workingDirectory, logDirectory = home, filepath.Join(home, "Library", "Logs")
+	}
+
+	standardOutPath = filepath.Join(logDirectory, darwin.name+".log")
+	standardErrorPath = filepath.Join(logDirectory, darwin.name+".err")
+
+	if darwin.workingDirectory != "" {
+		workingDirectory = darwin.workingDirectory
+	}
+	if darwin.standardOutPath != "" {
+		standardOutPath = darwin.standardOutPath
+	}
+	if darwin.standardErrorPath != "" {
+		standardErrorPath = darwin.standardErrorPath
+	}
+
+	return workingDirectory, standardOutPath, standardErrorPath, nil
+}
+
+// Check root rights for system daemons, per-user agents must not be managed by root,
+// otherwise they end up in the home directory and the domain of root
+func (darwin *darwinRecord) checkPrivileges() (bool, error) {
+	if darwin.userAgent {
+		if os.Geteuid() == 0 {
+			return false, ErrRootUserAgent
+		}
+		return true, nil
+	}
+	return checkPrivileges()
 }
 
 // Is a service installed
 func (darwin *darwinRecord) IsInstalled() (bool, error) {
-	_, err := os.Stat(darwin.servicePath())
+	srvPath, err := darwin.servicePath()
+	if err != nil {
+		return false, err
+	}
+
+	_, err = os.Stat(srvPath)
 	if err == nil {
 		return true, err
 	}
@@ -43,24 +225,49 @@ func (darwin *darwinRecord) IsInstalled() (bool, error) {
 
 // Get executable path
 func execPath() (string, error) {
-	return filepath.Abs(os.Args[0])
+	if name, err := executable(); err == nil {
+		return name, nil
+	}
+	return lookupExecPath(os.Args)
 }
 
-// Check service is running
-func (darwin *darwinRecord) checkRunning() (string, bool) {
-	output, err := exec.Command("launchctl", "list", darwin.name).Output()
-	if err == nil {
-		if matched, err := regexp.MatchString(darwin.name, string(output)); err == nil && matched {
-			reg := regexp.MustCompile("PID\" = ([0-9]+);")
-			data := reg.FindStringSubmatch(string(output))
-			if len(data) > 1 {
-				return "Service (pid  " + data[1] + ") is running...", true
-			}
-			return "Service is running...", true
+// Create working directory and log files of the service if they are missing,
+// so launchd does not end up with a service which is unable to write its logs
+func prepareDirectories(workingDirectory, standardOutPath, standardErrorPath string) error {
+	if err := os.MkdirAll(workingDirectory, 0755); err != nil {
+		return err
+	}
+
+	for _, path := range []string{standardOutPath, standardErrorPath} {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+		if err != nil {
+			return err
 		}
+		file.Close()
+	}
+
+	return nil
+}
+
+// Check service is running, a loaded service without pid
+// (e.g. an exited job which is not kept alive) is stopped
+func (darwin *darwinRecord) checkRunning() (string, bool) {
+	if pid, ok := darwin.getPid(); ok {
+		return "Service (pid  " + strconv.Itoa(pid) + ") is running...", true
 	}
 
-	return "Service is stopped", false
+	return StatusStopped.String(), false
+}
+
+// Unload the service from launchd
+func (darwin *darwinRecord) unload(srvPath string) error {
+	if darwin.userAgent {
+		return exec.Command("launchctl", "bootout", darwin.userDomain(), srvPath).Run()
+	}
+	return exec.Command("launchctl", "unload", srvPath).Run()
 }
 
 // Install the service
@@ -68,11 +275,14 @@ func (darwin *darwinRecord) Install(args ...string) (string, error) {
 	installAction := "Install " + darwin.description + ":"
 
 	var err error
-	if ok, err := checkPrivileges(); !ok {
+	if ok, err := darwin.checkPrivileges(); !ok {
 		return installAction + failed, err
 	}
 
-	srvPath := darwin.servicePath()
+	srvPath, err := darwin.servicePath()
+	if err != nil {
+		return installAction + failed, err
+	}
 
 	if check, err := darwin.IsInstalled(); check {
 		return installAction + failed, err
@@ -89,13 +299,29 @@ func (darwin *darwinRecord) Install(args ...string) (string, error) {
 		return installAction + failed, ErrIncorrectExecStartPath
 	}
 
+	workingDirectory, standardOutPath, standardErrorPath, err := darwin.paths()
+	if err != nil {
+		return installAction + failed, err
+	}
+
+	if err := prepareDirectories(workingDirectory, standardOutPath, standardErrorPath); err != nil {
+		return installAction + failed, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(srvPath), 0755); err != nil {
+		return installAction + failed, err
+	}
+
 	file, err := os.Create(srvPath)
 	if err != nil {
 		return installAction + failed, err
 	}
 	defer file.Close()
 
-	templ, err := template.New("propertyList").Parse(propertyList)
+	// escape values supplied by the caller, otherwise launchd rejects the property list
+	templ, err := template.New("propertyList").Funcs(
+		template.FuncMap{"xml": template.HTMLEscapeString},
+	).Parse(propertyList)
 	if err != nil {
 		return installAction + failed, err
 	}
@@ -103,9 +329,20 @@ func (darwin *darwinRecord) Install(args ...string) (string, error) {
 	if err := templ.Execute(
 		file,
 		&struct {
-			Name, Path string
-			Args       []string
-		}{darwin.name, darwin.execStartPath, args},
+			Name, Path                                           string
+			Args                                                 []string
+			KeepAlive, RunAtLoad                                 bool
+			WorkingDirectory, StandardOutPath, StandardErrorPath string
+		}{
+			darwin.name,
+			darwin.execStartPath,
+			args,
+			darwin.keepAlive,
+			darwin.runAtLoad,
+			workingDirectory,
+			standardOutPath,
+			standardErrorPath,
+		},
 	); err != nil {
 		return installAction + failed, err
 	}
@@ -117,7 +354,7 @@ func (darwin *darwinRecord) Install(args ...string) (string, error) {
 func (darwin *darwinRecord) Remove() (string, error) {
 	removeAction := "Removing " + darwin.description + ":"
 
-	if ok, err := checkPrivileges(); !ok {
+	if ok, err := darwin.checkPrivileges(); !ok {
 		return removeAction + failed, err
 	}
 
@@ -125,7 +362,19 @@ func (darwin *darwinRecord) Remove() (string, error) {
 		return removeAction + failed, err
 	}
 
-	if err := os.Remove(darwin.servicePath()); err != nil {
+	srvPath, err := darwin.servicePath()
+	if err != nil {
+		return removeAction + failed, err
+	}
+
+	// launchd must not keep the job of the removed property list
+	if _, err := darwin.launchctlStatus(); err == nil {
+		if err := darwin.unload(srvPath); err != nil {
+			return removeAction + failed, err
+		}
+	}
+
+	if err := os.Remove(srvPath); err != nil {
 		return removeAction + failed, err
 	}
 
@@ -136,7 +385,7 @@ func (darwin *darwinRecord) Remove() (string, error) {
 func (darwin *darwinRecord) Start() (string, error) {
 	startAction := "Starting " + darwin.description + ":"
 
-	if ok, err := checkPrivileges(); !ok {
+	if ok, err := darwin.checkPrivileges(); !ok {
 		return startAction + failed, err
 	}
 
@@ -148,7 +397,32 @@ func (darwin *darwinRecord) Start() (string, error) {
 		return startAction + failed, ErrAlreadyRunning
 	}
 
-	if err := exec.Command("launchctl", "load", darwin.servicePath()).Run(); err != nil {
+	srvPath, err := darwin.servicePath()
+	if err != nil {
+		return startAction + failed, err
+	}
+
+	// the job which is already loaded but has exited is only started again
+	if _, err := darwin.launchctlStatus(); err != nil {
+		load := exec.Command("launchctl", "load", srvPath)
+		if darwin.userAgent {
+			load = exec.Command("launchctl", "bootstrap", darwin.userDomain(), srvPath)
+		}
+		if err := load.Run(); err != nil {
+			return startAction + failed, err
+		}
+		// launchd starts the job on load by itself only if RunAtLoad is set
+		if darwin.runAtLoad {
+			return startAction + success, nil
+		}
+	}
+
+	start := exec.Command("launchctl", "start", darwin.name)
+	if darwin.userAgent {
+		start = exec.Command("launchctl", "kickstart", darwin.userDomain()+"/"+darwin.name)
+	}
+
+	if err := start.Run(); err != nil {
 		return startAction + failed, err
 	}
 
@@ -159,7 +433,7 @@ func (darwin *darwinRecord) Start() (string, error) {
 func (darwin *darwinRecord) Stop() (string, error) {
 	stopAction := "Stopping " + darwin.description + ":"
 
-	if ok, err := checkPrivileges(); !ok {
+	if ok, err := darwin.checkPrivileges(); !ok {
 		return stopAction + failed, err
 	}
 
@@ -167,26 +441,60 @@ func (darwin *darwinRecord) Stop() (string, error) {
 		return stopAction + failed, err
 	}
 
-	if _, ok := darwin.checkRunning(); !ok {
+	// a loaded job is unloaded even if it has exited, otherwise it stays in launchd
+	if _, err := darwin.launchctlStatus(); err != nil {
 		return stopAction + failed, ErrAlreadyStopped
 	}
 
-	if err := exec.Command("launchctl", "unload", darwin.servicePath()).Run(); err != nil {
+	srvPath, err := darwin.servicePath()
+	if err != nil {
+		return stopAction + failed, err
+	}
+
+	if err := darwin.unload(srvPath); err != nil {
 		return stopAction + failed, err
 	}
 
 	return stopAction + success, nil
 }
 
+// Reload the service by sending SIGHUP, launchd has no native reload
+func (darwin *darwinRecord) Reload() (string, error) {
+	reloadAction := "Reloading " + darwin.description + ":"
+
+	if ok, err := darwin.checkPrivileges(); !ok {
+		return reloadAction + failed, err
+	}
+
+	if check, err := darwin.IsInstalled(); !check {
+		return reloadAction + failed, err
+	}
+
+	if _, ok := darwin.checkRunning(); !ok {
+		return reloadAction + failed, ErrAlreadyStopped
+	}
+
+	pid, ok := darwin.getPid()
+	if !ok {
+		return reloadAction + failed, ErrUnableToReload
+	}
+
+	if err := signalReload(pid); err != nil {
+		return reloadAction + failed, err
+	}
+
+	return reloadAction + success, nil
+}
+
 // Status - Get service status
 func (darwin *darwinRecord) Status() (string, error) {
 
-	if ok, err := checkPrivileges(); !ok {
+	if ok, err := darwin.checkPrivileges(); !ok {
 		return "", err
 	}
 
 	if check, err := darwin.IsInstalled(); !check {
-		return "Status could not defined", err
+		return StatusNotInstalled.String(), err
 	}
 
 	statusAction, _ := darwin.checkRunning()
@@ -194,6 +502,27 @@ func (darwin *darwinRecord) Status() (string, error) {
 	return statusAction, nil
 }
 
+// GetStatus - Get service status as Status value
+func (darwin *darwinRecord) GetStatus() (Status, error) {
+
+	if ok, err := darwin.checkPrivileges(); !ok {
+		return StatusUnknown, err
+	}
+
+	if check, err := darwin.IsInstalled(); !check {
+		if os.IsNotExist(err) {
+			return StatusNotInstalled, nil
+		}
+		return StatusUnknown, err
+	}
+
+	if _, ok := darwin.checkRunning(); ok {
+		return StatusRunning, nil
+	}
+
+	return StatusStopped, nil
+}
+
 // Run - Run service
 func (darwin *darwinRecord) Run(e Executable) (string, error) {
 	runAction := "Running " + darwin.description + ":"
@@ -206,23 +535,23 @@ var propertyList = `<?xml version="1.0" encoding="UTF-8"?>
 <plist version="1.0">
 <dict>
 	<key>KeepAlive</key>
-	<true/>
+	{{if .KeepAlive}}<true/>{{else}}<false/>{{end}}
 	<key>Label</key>
-	<string>{{.Name}}</string>
+	<string>{{xml .Name}}</string>
 	<key>ProgramArguments</key>
 	<array>
-	    <string>{{.Path}}</string>
-		{{range .Args}}<string>{{.}}</string>
+	    <string>{{xml .Path}}</string>
+		{{range .Args}}<string>{{xml .}}</string>
 		{{end}}
 	</array>
 	<key>RunAtLoad</key>
-	<true/>
+	{{if .RunAtLoad}}<true/>{{else}}<false/>{{end}}
     <key>WorkingDirectory</key>
-    <string>/usr/local/var</string>
+    <string>{{xml .WorkingDirectory}}</string>
     <key>StandardErrorPath</key>
-    <string>/usr/local/var/log/{{.Name}}.err</string>
+    <string>{{xml .StandardErrorPath}}</string>
     <key>StandardOutPath</key>
-    <string>/usr/local/var/log/{{.Name}}.log</string>
+    <string>{{xml .StandardOutPath}}</string>
 </dict>
 </plist>
 `
@@ -5,7 +5,6 @@ import (
 	"io/ioutil"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"regexp"
 	"strings"
 	"text/template"
@@ -17,6 +16,7 @@ type bsdRecord struct {
 	description   string
 	execStartPath string
 	dependencies  []string
+	pidFile       string
 }
 
 // Standard service path for systemV daemons
@@ -68,20 +68,28 @@ func (bsd *bsdRecord) getCmd(cmd string) string {
 
 // Get the daemon properly
 func newDaemon(name, description, execStartPath string, dependencies []string) (Daemon, error) {
-	return &bsdRecord{name, description, execStartPath,dependencies}, nil
+	return &bsdRecord{name, description, execStartPath, dependencies, ""}, nil
 }
 
-func execPath() (name string, err error) {
-	name = os.Args[0]
-	if name[0] == '.' {
-		name, err = filepath.Abs(name)
-		if err == nil {
-			name = filepath.Clean(name)
-		}
-	} else {
-		name, err = exec.LookPath(filepath.Clean(name))
+// SetPidFile - set pidfile of the rc.d script (default: /var/run/$name.pid)
+func (bsd *bsdRecord) SetPidFile(path string) {
+	bsd.pidFile = path
+}
+
+// Pid file of the service
+func (bsd *bsdRecord) pidFilePath() string {
+	if bsd.pidFile != "" {
+		return bsd.pidFile
 	}
-	return name, err
+	return "/var/run/" + bsd.name + ".pid"
+}
+
+// Get executable path
+func execPath() (string, error) {
+	if name, err := executable(); err == nil {
+		return name, nil
+	}
+	return lookupExecPath(os.Args)
 }
 
 // Check service is running
@@ -94,11 +102,11 @@ func (bsd *bsdRecord) checkRunning() (string, bool) {
 			if len(data) > 1 {
 				return "Service (pid  " + data[1] + ") is running...", true
 			}
-			return "Service is running...", true
+			return StatusRunning.String(), true
 		}
 	}
 
-	return "Service is stopped", false
+	return StatusStopped.String(), false
 }
 
 // Install the service
@@ -141,8 +149,8 @@ func (bsd *bsdRecord) Install(args ...string) (string, error) {
 	if err := templ.Execute(
 		file,
 		&struct {
-			Name, Description, Path, Args string
-		}{bsd.name, bsd.description, bsd.execStartPath, strings.Join(args, " ")},
+			Name, Description, Path, Args, PidFile string
+		}{bsd.name, bsd.description, bsd.execStartPath, strings.Join(args, " "), bsd.pidFilePath()},
 	); err != nil {
 		return installAction + failed, err
 	}
@@ -219,6 +227,36 @@ func (bsd *bsdRecord) Stop() (string, error) {
 	return stopAction + success, nil
 }
 
+// Reload the service
+func (bsd *bsdRecord) Reload() (string, error) {
+	reloadAction := "Reloading " + bsd.description + ":"
+
+	if ok, err := checkPrivileges(); !ok {
+		return reloadAction + failed, err
+	}
+
+	if check, err := bsd.IsInstalled(); !check {
+		return reloadAction + failed, err
+	}
+
+	if _, ok := bsd.checkRunning(); !ok {
+		return reloadAction + failed, ErrAlreadyStopped
+	}
+
+	if err := exec.Command("service", bsd.name, bsd.getCmd("reload")).Run(); err != nil {
+		// rc.d script may have no reload command, signal the process directly
+		pid, err := readPidFile(bsd.pidFilePath())
+		if err != nil {
+			return reloadAction + failed, err
+		}
+		if err := signalReload(pid); err != nil {
+			return reloadAction + failed, err
+		}
+	}
+
+	return reloadAction + success, nil
+}
+
 // Status - Get service status
 func (bsd *bsdRecord) Status() (string, error) {
 
@@ -227,7 +265,7 @@ func (bsd *bsdRecord) Status() (string, error) {
 	}
 
 	if check, err := bsd.IsInstalled(); !check {
-		return "Status could not defined", err
+		return StatusNotInstalled.String(), err
 	}
 
 	statusAction, _ := bsd.checkRunning()
@@ -235,6 +273,27 @@ func (bsd *bsdRecord) Status() (string, error) {
 	return statusAction, nil
 }
 
+// GetStatus - Get service status as Status value
+func (bsd *bsdRecord) GetStatus() (Status, error) {
+
+	if ok, err := checkPrivileges(); !ok {
+		return StatusUnknown, err
+	}
+
+	if check, err := bsd.IsInstalled(); !check {
+		if os.IsNotExist(err) {
+			return StatusNotInstalled, nil
+		}
+		return StatusUnknown, err
+	}
+
+	if _, ok := bsd.checkRunning(); ok {
+		return StatusRunning, nil
+	}
+
+	return StatusStopped, nil
+}
+
 // Run - Run service
 func (bsd *bsdRecord) Run(e Executable) (string, error) {
 	runAction := "Running " + bsd.description + ":"
@@ -259,7 +318,8 @@ var bsdConfig = `#!/bin/sh
 name="{{.Name}}"
 rcvar="{{.Name}}_enable"
 command="{{.Path}}"
-pidfile="/var/run/$name.pid"
+pidfile="{{.PidFile}}"
+extra_commands="reload"
 
 start_cmd="/usr/sbin/daemon -p $pidfile -f $command {{.Args}}"
 load_rc_config $name
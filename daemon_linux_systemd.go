@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/exec"
 	"regexp"
+	"strconv"
 	"strings"
 	"text/template"
 )
@@ -45,11 +46,11 @@ func (linux *systemDRecord) checkRunning() (string, bool) {
 			if len(data) > 1 {
 				return "Service (pid  " + data[1] + ") is running...", true
 			}
-			return "Service is running...", true
+			return StatusRunning.String(), true
 		}
 	}
 
-	return "Service is stopped", false
+	return StatusStopped.String(), false
 }
 
 // Install the service
@@ -184,6 +185,44 @@ func (linux *systemDRecord) Stop() (string, error) {
 	return stopAction + success, nil
 }
 
+// Reload the service
+func (linux *systemDRecord) Reload() (string, error) {
+	reloadAction := "Reloading " + linux.description + ":"
+
+	if ok, err := checkPrivileges(); !ok {
+		return reloadAction + failed, err
+	}
+
+	if check, err := linux.IsInstalled(); !check {
+		return reloadAction + failed, err
+	}
+
+	if _, ok := linux.checkRunning(); !ok {
+		return reloadAction + failed, ErrAlreadyStopped
+	}
+
+	if err := exec.Command("systemctl", "reload", linux.name+".service").Run(); err != nil {
+		// unit may have no ExecReload (installed by previous versions), signal the main process directly
+		output, err := exec.Command("systemctl", "show", "-p", "MainPID", linux.name+".service").Output()
+		if err != nil {
+			return reloadAction + failed, ErrUnableToReload
+		}
+		data := regexp.MustCompile("MainPID=([0-9]+)").FindStringSubmatch(string(output))
+		if len(data) < 2 {
+			return reloadAction + failed, ErrUnableToReload
+		}
+		pid, err := strconv.Atoi(data[1])
+		if err != nil {
+			return reloadAction + failed, ErrUnableToReload
+		}
+		if err := signalReload(pid); err != nil {
+			return reloadAction + failed, err
+		}
+	}
+
+	return reloadAction + success, nil
+}
+
 // Status - Get service status
 func (linux *systemDRecord) Status() (string, error) {
 
@@ -192,7 +231,7 @@ func (linux *systemDRecord) Status() (string, error) {
 	}
 
 	if check, err := linux.IsInstalled(); !check {
-		return "Status could not defined", err
+		return StatusNotInstalled.String(), err
 	}
 
 	statusAction, _ := linux.checkRunning()
@@ -200,6 +239,27 @@ func (linux *systemDRecord) Status() (string, error) {
 	return statusAction, nil
 }
 
+// GetStatus - Get service status as Status value
+func (linux *systemDRecord) GetStatus() (Status, error) {
+
+	if ok, err := checkPrivileges(); !ok {
+		return StatusUnknown, err
+	}
+
+	if check, err := linux.IsInstalled(); !check {
+		if os.IsNotExist(err) {
+			return StatusNotInstalled, nil
+		}
+		return StatusUnknown, err
+	}
+
+	if _, ok := linux.checkRunning(); ok {
+		return StatusRunning, nil
+	}
+
+	return StatusStopped, nil
+}
+
 // Run - Run service
 func (linux *systemDRecord) Run(e Executable) (string, error) {
 	runAction := "Running " + linux.description + ":"
@@ -216,6 +276,7 @@ After={{.Dependencies}}
 PIDFile=/var/run/{{.Name}}.pid
 ExecStartPre=/bin/rm -f /var/run/{{.Name}}.pid
 ExecStart={{.Path}} {{.Args}}
+ExecReload=/bin/kill -HUP $MAINPID
 Restart=on-failure
 
 [Install]
@@ -45,11 +45,11 @@ func (linux *systemVRecord) checkRunning() (string, bool) {
 			if len(data) > 1 {
 				return "Service (pid  " + data[1] + ") is running...", true
 			}
-			return "Service is running...", true
+			return StatusRunning.String(), true
 		}
 	}
 
-	return "Service is stopped", false
+	return StatusStopped.String(), false
 }
 
 // Install the service
@@ -192,6 +192,37 @@ func (linux *systemVRecord) Stop() (string, error) {
 	return stopAction + success, nil
 }
 
+// Reload the service
+func (linux *systemVRecord) Reload() (string, error) {
+	reloadAction := "Reloading " + linux.description + ":"
+
+	if ok, err := checkPrivileges(); !ok {
+		return reloadAction + failed, err
+	}
+
+	if check, err := linux.IsInstalled(); !check {
+		return reloadAction + failed, err
+	}
+
+	if _, ok := linux.checkRunning(); !ok {
+		return reloadAction + failed, ErrAlreadyStopped
+	}
+
+	if err := exec.Command("service", linux.name, "reload").Run(); err != nil {
+		// init script may have no reload command (installed by previous versions),
+		// signal the process directly
+		pid, err := readPidFile("/var/run/" + linux.name + ".pid")
+		if err != nil {
+			return reloadAction + failed, err
+		}
+		if err := signalReload(pid); err != nil {
+			return reloadAction + failed, err
+		}
+	}
+
+	return reloadAction + success, nil
+}
+
 // Status - Get service status
 func (linux *systemVRecord) Status() (string, error) {
 
@@ -200,7 +231,7 @@ func (linux *systemVRecord) Status() (string, error) {
 	}
 
 	if check, err := linux.IsInstalled(); !check {
-		return "Status could not defined", err
+		return StatusNotInstalled.String(), err
 	}
 
 	statusAction, _ := linux.checkRunning()
@@ -208,6 +239,27 @@ func (linux *systemVRecord) Status() (string, error) {
 	return statusAction, nil
 }
 
+// GetStatus - Get service status as Status value
+func (linux *systemVRecord) GetStatus() (Status, error) {
+
+	if ok, err := checkPrivileges(); !ok {
+		return StatusUnknown, err
+	}
+
+	if check, err := linux.IsInstalled(); !check {
+		if os.IsNotExist(err) {
+			return StatusNotInstalled, nil
+		}
+		return StatusUnknown, err
+	}
+
+	if _, ok := linux.checkRunning(); ok {
+		return StatusRunning, nil
+	}
+
+	return StatusStopped, nil
+}
+
 // Run - Run service
 func (linux *systemVRecord) Run(e Executable) (string, error) {
 	runAction := "Running " + linux.description + ":"
@@ -291,6 +343,14 @@ stop() {
     return $retval
 }
 
+reload() {
+    echo -n $"Reloading $servname: "
+    killproc -p $pidfile $proc -HUP
+    retval=$?
+    echo
+    return $retval
+}
+
 restart() {
     stop
     start
@@ -316,11 +376,15 @@ case "$1" in
     restart)
         $1
         ;;
+    reload)
+        rh_status_q || exit 7
+        $1
+        ;;
     status)
         rh_status
         ;;
     *)
-        echo $"Usage: $0 {start|stop|status|restart}"
+        echo $"Usage: $0 {start|stop|status|restart|reload}"
         exit 2
 esac
 
@@ -45,11 +45,11 @@ func (linux *upstartRecord) checkRunning() (string, bool) {
 			if len(data) > 1 {
 				return "Service (pid  " + data[1] + ") is running...", true
 			}
-			return "Service is running...", true
+			return StatusRunning.String(), true
 		}
 	}
 
-	return "Service is stopped", false
+	return StatusStopped.String(), false
 }
 
 // Install the service
@@ -170,6 +170,29 @@ func (linux *upstartRecord) Stop() (string, error) {
 	return stopAction + success, nil
 }
 
+// Reload the service
+func (linux *upstartRecord) Reload() (string, error) {
+	reloadAction := "Reloading " + linux.description + ":"
+
+	if ok, err := checkPrivileges(); !ok {
+		return reloadAction + failed, err
+	}
+
+	if check, err := linux.IsInstalled(); !check {
+		return reloadAction + failed, err
+	}
+
+	if _, ok := linux.checkRunning(); !ok {
+		return reloadAction + failed, ErrAlreadyStopped
+	}
+
+	if err := exec.Command("reload", linux.name).Run(); err != nil {
+		return reloadAction + failed, err
+	}
+
+	return reloadAction + success, nil
+}
+
 // Status - Get service status
 func (linux *upstartRecord) Status() (string, error) {
 
@@ -178,7 +201,7 @@ func (linux *upstartRecord) Status() (string, error) {
 	}
 
 	if check, err := linux.IsInstalled(); !check {
-		return "Status could not defined", err
+		return StatusNotInstalled.String(), err
 	}
 
 	statusAction, _ := linux.checkRunning()
@@ -186,6 +209,27 @@ func (linux *upstartRecord) Status() (string, error) {
 	return statusAction, nil
 }
 
+// GetStatus - Get service status as Status value
+func (linux *upstartRecord) GetStatus() (Status, error) {
+
+	if ok, err := checkPrivileges(); !ok {
+		return StatusUnknown, err
+	}
+
+	if check, err := linux.IsInstalled(); !check {
+		if os.IsNotExist(err) {
+			return StatusNotInstalled, nil
+		}
+		return StatusUnknown, err
+	}
+
+	if _, ok := linux.checkRunning(); ok {
+		return StatusRunning, nil
+	}
+
+	return StatusStopped, nil
+}
+
 // Run - Run service
 func (linux *upstartRecord) Run(e Executable) (string, error) {
 	runAction := "Running " + linux.description + ":"
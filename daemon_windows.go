@@ -14,6 +14,7 @@ import (
 	"time"
 	"unicode/utf16"
 	"unsafe"
+	winapi "golang.org/x/sys/windows"
 	"golang.org/x/sys/windows/registry"
 	"golang.org/x/sys/windows/svc"
 	"golang.org/x/sys/windows/svc/mgr"
@@ -150,6 +151,25 @@ func (windows *windowsRecord) Stop() (string, error) {
 	return stopAction + " completed.", nil
 }
 
+// Reload the service, windows services have no reload command
+func (windows *windowsRecord) Reload() (string, error) {
+	reloadAction := "Reloading " + windows.description + ":"
+
+	status, err := windows.GetStatus()
+	if err != nil {
+		return reloadAction + failed, err
+	}
+
+	switch status {
+	case StatusNotInstalled:
+		return reloadAction + failed, ErrNotInstalled
+	case StatusStopped:
+		return reloadAction + failed, ErrAlreadyStopped
+	}
+
+	return reloadAction + failed, ErrUnableToReload
+}
+
 func stopAndWait(s *mgr.Service) error {
 	// First stop the service. Then wait for the service to
 	// actually stop before starting it.
@@ -216,6 +236,36 @@ func (windows *windowsRecord) Status() (string, error) {
 	return "Status: " + getWindowsServiceStateFromUint32(status.State), nil
 }
 
+// GetStatus - Get service status as Status value, pending and paused states
+// are reported as StatusUnknown, Status gives the exact state of the service
+func (windows *windowsRecord) GetStatus() (Status, error) {
+	m, err := mgr.Connect()
+	if err != nil {
+		return StatusUnknown, getWindowsError(err)
+	}
+	defer m.Disconnect()
+	s, err := m.OpenService(windows.name)
+	if err != nil {
+		if err == winapi.ERROR_SERVICE_DOES_NOT_EXIST {
+			return StatusNotInstalled, nil
+		}
+		return StatusUnknown, getWindowsError(err)
+	}
+	defer s.Close()
+	status, err := s.Query()
+	if err != nil {
+		return StatusUnknown, getWindowsError(err)
+	}
+
+	switch status.State {
+	case svc.Running:
+		return StatusRunning, nil
+	case svc.Stopped:
+		return StatusStopped, nil
+	}
+	return StatusUnknown, nil
+}
+
 // Get executable path
 func execPath() (string, error) {
 	var n uint32
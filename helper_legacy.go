@@ -8,10 +8,13 @@ package daemon
 
 import (
 	"errors"
+	"io/ioutil"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"syscall"
 )
 
 // Service constants
@@ -38,6 +41,21 @@ var (
 
 	// ErrAlreadyStopped appears if try to stop already stopped service
 	ErrAlreadyStopped = errors.New("Service has already been stopped")
+
+	// ErrRootUserAgent appears if try to manage per-user agent with root privileges
+	ErrRootUserAgent = errors.New("Per-user agent must be managed by its user. Possibly running without 'sudo' command should help")
+
+	// ErrHomeDirectory appears if home directory of the current user could not be found
+	ErrHomeDirectory = errors.New("Home directory of the current user could not be found")
+
+	// ErrIncorrectPidFile appears if pid file does not contain a valid pid of the process
+	ErrIncorrectPidFile = errors.New("Incorrect pid file")
+
+	// ErrUnableToReload appears if try to reload service which could not be reloaded on the system
+	ErrUnableToReload = errors.New("Service could not be reloaded")
+
+	// ErrIncorrectExecStartPath appears if try to path folder or incorrect exec path start for service
+	ErrIncorrectExecStartPath = errors.New("Incorrect exec start path")
 )
 
 // ExecPath tries to get executable path
@@ -45,6 +63,29 @@ func ExecPath() (string, error) {
 	return execPath()
 }
 
+// Get path of the running executable, which is not available before go1.8
+func executable() (string, error) {
+	return "", ErrUnsupportedSystem
+}
+
+// Resolve executable path from the command line the program was started with
+func lookupExecPath(args []string) (string, error) {
+	if len(args) == 0 || args[0] == "" {
+		return "", ErrIncorrectExecStartPath
+	}
+
+	name := args[0]
+	if !strings.ContainsRune(name, filepath.Separator) {
+		path, err := exec.LookPath(name)
+		if err != nil {
+			return "", err
+		}
+		name = path
+	}
+
+	return filepath.Abs(name)
+}
+
 // Lookup path for executable file
 func executablePath(name string) (string, error) {
 	if path, err := exec.LookPath(name); err == nil {
@@ -68,3 +109,29 @@ func checkPrivileges() (bool, error) {
 	}
 	return false, ErrUnsupportedSystem
 }
+
+// Read process id from the pid file
+func readPidFile(path string) (int, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return 0, ErrIncorrectPidFile
+	}
+	return pid, nil
+}
+
+// Send SIGHUP to the process of the service, pid 0 or -1 would
+// signal the whole process group or every process of the caller
+func signalReload(pid int) error {
+	if pid <= 0 {
+		return ErrUnableToReload
+	}
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	return process.Signal(syscall.SIGHUP)
+}
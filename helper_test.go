@@ -0,0 +1,66 @@
+// Copyright 2016 The Go Authors. All rights reserved.
+// Use of this source code is governed by
+// license that can be found in the LICENSE file.
+
+//+build go1.17
+
+package daemon
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestLookupExecPath(t *testing.T) {
+	dir := t.TempDir()
+
+	bare := "daemon-test-exec"
+	if runtime.GOOS == "windows" {
+		bare += ".exe"
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, bare), []byte{}, 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	absolute := filepath.Join(dir, "x")
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+		err      bool
+	}{
+		{"nil", nil, "", true},
+		{"empty", []string{""}, "", true},
+		{"relative dot", []string{"." + string(filepath.Separator) + "x"}, filepath.Join(wd, "x"), false},
+		{"bare name", []string{bare}, filepath.Join(dir, bare), false},
+		{"unknown bare name", []string{"daemon-test-unknown"}, "", true},
+		{"absolute", []string{absolute}, absolute, false},
+	}
+
+	for _, test := range tests {
+		path, err := lookupExecPath(test.args)
+		if test.err {
+			if err == nil {
+				t.Errorf("%s: expected error, got path %q", test.name, path)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if path != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.name, test.expected, path)
+		}
+	}
+}